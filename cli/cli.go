@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"time"
 
-	"github.com/mhpenta/yttext"
-	"github.com/mhpenta/yttext/formatters"
+	"github.com/mhpenta/yttext/pkg/api"
+	"github.com/mhpenta/yttext/pkg/formatters"
 
 	"github.com/atotto/clipboard"
 )
@@ -20,6 +23,18 @@ type CLI struct {
 	Readable     bool
 	Copy         bool
 	VideoURL     string
+	PlaylistURL  string
+	ChannelURL   string
+	Concurrency  int
+	ListLangs    bool
+	Translate    string
+	Cache        bool
+	NoCache      bool
+	CacheDir     string
+	CacheTTL     time.Duration
+	Timeout      time.Duration
+	Proxy        string
+	Retries      int
 }
 
 // NewCLI creates a new CLI instance with parsed command-line arguments
@@ -29,13 +44,27 @@ func NewCLI() *CLI {
 	flag.BoolVar(&cli.Debug, "debug", false, "Enable debug mode (writes API response to yttext_debug.json)")
 	flag.BoolVar(&cli.LogRequest, "log-request", false, "Log API request details without full debug output")
 	flag.StringVar(&cli.LanguageCode, "lang", "en", "Language code for transcript (e.g., 'en', 'es', 'fr')")
-	flag.StringVar(&cli.FormatType, "format", "text", "Output format (text, json, srt, readable)")
+	flag.StringVar(&cli.FormatType, "format", "text", "Output format (text, json, srt, vtt, ttml, readable)")
 	flag.BoolVar(&cli.Readable, "readable", false, "Use readable format (same as --format=readable)")
 	flag.BoolVar(&cli.Copy, "copy", false, "Copy output to clipboard in addition to stdout")
+	flag.StringVar(&cli.PlaylistURL, "playlist", "", "Fetch transcripts for every video in a YouTube playlist URL")
+	flag.StringVar(&cli.ChannelURL, "channel", "", "Fetch transcripts for every video on a YouTube channel URL")
+	flag.IntVar(&cli.Concurrency, "concurrency", 5, "Number of videos to fetch concurrently with --playlist/--channel")
+	flag.BoolVar(&cli.ListLangs, "list-langs", false, "List available caption languages for the video instead of fetching a transcript")
+	flag.StringVar(&cli.Translate, "translate", "", "Translate the transcript to the given language code before output")
+	flag.BoolVar(&cli.Cache, "cache", true, "Cache fetched transcripts on disk")
+	flag.BoolVar(&cli.NoCache, "no-cache", false, "Disable the on-disk transcript cache (overrides --cache)")
+	flag.StringVar(&cli.CacheDir, "cache-dir", "", "Directory for the on-disk transcript cache (default: $XDG_CACHE_HOME/yttext)")
+	flag.DurationVar(&cli.CacheTTL, "cache-ttl", 24*time.Hour, "How long cached transcripts remain valid")
+	flag.DurationVar(&cli.Timeout, "timeout", 30*time.Second, "Timeout for the overall request")
+	flag.StringVar(&cli.Proxy, "proxy", "", "Proxy URL to route requests through (e.g. http://localhost:8080)")
+	flag.IntVar(&cli.Retries, "retries", 2, "Number of retries on HTTP 429/5xx responses")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [options] \"youtube_url\"\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Get transcript from YouTube video and print it to stdout\n\n")
+		fmt.Fprintf(os.Stderr, "       %s [options] --playlist \"playlist_url\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s [options] --channel \"channel_url\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Get transcript(s) from YouTube and print them to stdout\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nNote: Remember to put quotes around the YouTube URL to avoid shell interpretation issues\n")
@@ -43,13 +72,14 @@ func NewCLI() *CLI {
 
 	flag.Parse()
 
-	if flag.NArg() != 1 {
-		flag.Usage()
-		os.Exit(1)
+	if cli.PlaylistURL == "" && cli.ChannelURL == "" {
+		if flag.NArg() != 1 {
+			flag.Usage()
+			os.Exit(1)
+		}
+		cli.VideoURL = flag.Arg(0)
 	}
 
-	cli.VideoURL = flag.Arg(0)
-
 	return cli
 }
 
@@ -67,9 +97,56 @@ func (c *CLI) Run() int {
 		c.FormatType = "readable"
 	}
 
-	ytAPI := yttext.New()
+	var opts []api.Option
+	if c.Cache && !c.NoCache {
+		cache, err := api.NewFileCache(c.CacheDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to initialize cache: %v\n", err)
+		} else {
+			opts = append(opts, api.WithCache(cache), api.WithCacheTTL(c.CacheTTL))
+		}
+	}
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid proxy URL: %v\n", err)
+			return 1
+		}
+		opts = append(opts, api.WithProxy(proxyURL))
+	}
+
+	opts = append(opts, api.WithRetry(c.Retries, 500*time.Millisecond))
+
+	ytAPI := api.New(opts...)
+	ytAPI.Concurrency = c.Concurrency
+
+	ctx := context.Background()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	if c.PlaylistURL != "" {
+		return c.runBatch(ytAPI.GetTranscriptsByPlaylist(ctx, c.PlaylistURL, c.LanguageCode))
+	}
+
+	if c.ChannelURL != "" {
+		return c.runBatch(ytAPI.GetTranscriptsByChannel(ctx, c.ChannelURL, c.LanguageCode))
+	}
+
+	if c.ListLangs {
+		return c.runListLangs(ctx, ytAPI)
+	}
 
-	transcripts, err := ytAPI.GetTranscriptsByURL(c.VideoURL, c.LanguageCode)
+	var transcripts []api.Transcript
+	var err error
+	if c.Translate != "" {
+		transcripts, err = ytAPI.GetTranslatedTranscriptByURL(ctx, c.VideoURL, c.LanguageCode, c.Translate)
+	} else {
+		transcripts, err = ytAPI.GetTranscriptsByURL(ctx, c.VideoURL, c.LanguageCode)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 
@@ -113,3 +190,49 @@ func (c *CLI) Run() int {
 	fmt.Print(output)
 	return 0
 }
+
+// runBatch formats and prints the transcripts fetched for a playlist or
+// channel, reporting any per-video errors to stderr. It returns 1 if the
+// format type is unknown or if no video produced a transcript.
+func (c *CLI) runBatch(transcripts map[string][]api.Transcript, errs map[string]error) int {
+	formatter, err := formatters.NewFormatter(c.FormatType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	for videoID, err := range errs {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", videoID, err)
+	}
+
+	for videoID, ts := range transcripts {
+		output, err := formatter.Format(ts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", videoID, err)
+			continue
+		}
+		fmt.Printf("=== %s ===\n%s\n", videoID, output)
+	}
+
+	if len(transcripts) == 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// runListLangs prints the caption languages available for a video as a table.
+func (c *CLI) runListLangs(ctx context.Context, ytAPI *api.TranscriptAPI) int {
+	tracks, err := ytAPI.ListAvailableTranscriptsByURL(ctx, c.VideoURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("%-10s %-25s %-10s %-12s\n", "CODE", "LANGUAGE", "GENERATED", "TRANSLATABLE")
+	for _, t := range tracks {
+		fmt.Printf("%-10s %-25s %-10t %-12t\n", t.LanguageCode, t.LanguageName, t.IsGenerated, t.IsTranslatable)
+	}
+
+	return 0
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// safeCacheKey matches the video ID/language code shapes yttext expects
+// (YouTube video IDs and BCP-47-ish language codes). Inputs that don't match
+// are hashed instead of being used directly, since videoID and lang can
+// originate from untrusted third-party HTML (playlist/channel scraping) and
+// must never be interpolated into a filesystem path unsanitized.
+var safeCacheKey = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Cache stores fetched transcripts so repeated requests for the same video
+// and language don't need to hit YouTube again.
+type Cache interface {
+	// Get returns the cached transcripts for videoID/lang and whether a
+	// live (non-expired) entry was found.
+	Get(videoID, lang string) ([]Transcript, bool)
+	// Put stores transcripts for videoID/lang, valid for ttl.
+	Put(videoID, lang string, transcripts []Transcript, ttl time.Duration)
+}
+
+// FileCache is a Cache backed by one JSON file per video/language pair,
+// stored under a configurable directory.
+type FileCache struct {
+	dir string
+}
+
+// cacheEntry is the on-disk representation written by FileCache.
+type cacheEntry struct {
+	Transcripts []Transcript `json:"transcripts"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+}
+
+// NewFileCache creates a FileCache rooted at dir, creating it if necessary.
+// If dir is empty, it defaults to $XDG_CACHE_HOME/yttext, falling back to
+// $HOME/.cache/yttext when XDG_CACHE_HOME is unset.
+func NewFileCache(dir string) (*FileCache, error) {
+	if dir == "" {
+		cacheHome := os.Getenv("XDG_CACHE_HOME")
+		if cacheHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to determine cache directory: %v", err)
+			}
+			cacheHome = filepath.Join(home, ".cache")
+		}
+		dir = filepath.Join(cacheHome, "yttext")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+// path returns the on-disk path for a video/language pair's cache entry.
+// videoID and lang are not trusted to be filesystem-safe (videoID in
+// particular can originate from scraped playlist/channel HTML), so either
+// is replaced with a hash of itself if it doesn't look like a plain
+// YouTube video ID or language code.
+func (c *FileCache) path(videoID, lang string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%s_%s.json", cacheKeyComponent(videoID), cacheKeyComponent(lang)))
+}
+
+// cacheKeyComponent returns s unchanged if it's safe to use as a filename
+// component, or a hex-encoded SHA-256 hash of it otherwise.
+func cacheKeyComponent(s string) string {
+	if safeCacheKey.MatchString(s) {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return fmt.Sprintf("%x", sum)
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(videoID, lang string) ([]Transcript, bool) {
+	data, err := os.ReadFile(c.path(videoID, lang))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+
+	return entry.Transcripts, true
+}
+
+// Put implements Cache.
+func (c *FileCache) Put(videoID, lang string, transcripts []Transcript, ttl time.Duration) {
+	entry := cacheEntry{
+		Transcripts: transcripts,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(c.path(videoID, lang), data, 0o644)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyComponent(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string // non-empty means the input is expected to pass through unchanged
+	}{
+		{name: "plain video ID", in: "dQw4w9WgXcQ", want: "dQw4w9WgXcQ"},
+		{name: "plain language code", in: "en-US", want: "en-US"},
+		{name: "path traversal", in: "../../../../tmp/evil"},
+		{name: "embedded slash", in: "foo/bar"},
+		{name: "empty string", in: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := cacheKeyComponent(tc.in)
+
+			if tc.want != "" {
+				if got != tc.want {
+					t.Errorf("cacheKeyComponent(%q) = %q, want %q", tc.in, got, tc.want)
+				}
+				return
+			}
+
+			if got == "" || strings.ContainsAny(got, "/\\") || strings.Contains(got, "..") {
+				t.Errorf("cacheKeyComponent(%q) = %q, not safe for use as a filename component", tc.in, got)
+			}
+		})
+	}
+}
+
+func TestFileCachePathStaysWithinDir(t *testing.T) {
+	c := &FileCache{dir: t.TempDir()}
+
+	traversalIDs := []string{"../../../../tmp/evil", "foo/bar", "", "..", "."}
+
+	for _, videoID := range traversalIDs {
+		got := c.path(videoID, "../escape")
+
+		rel, err := filepath.Rel(c.dir, got)
+		if err != nil {
+			t.Fatalf("path(%q, ...) = %q, not relative to cache dir: %v", videoID, got, err)
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			t.Errorf("path(%q, ...) = %q escapes cache dir (rel %q)", videoID, got, rel)
+		}
+		if filepath.Dir(got) != c.dir {
+			t.Errorf("path(%q, ...) = %q, want a file directly inside %q", videoID, got, c.dir)
+		}
+	}
+}
+
+func TestFileCacheGetPutRoundTrip(t *testing.T) {
+	c := &FileCache{dir: t.TempDir()}
+
+	videoID := "../../../../tmp/evil"
+	lang := "en"
+	want := []Transcript{{Text: "hello", Duration: 1.5, Offset: 0, StartTime: 0}}
+
+	c.Put(videoID, lang, want, time.Minute)
+
+	got, ok := c.Get(videoID, lang)
+	if !ok {
+		t.Fatalf("Get(%q, %q) returned ok=false after Put", videoID, lang)
+	}
+	if len(got) != 1 || got[0].Text != want[0].Text {
+		t.Errorf("Get(%q, %q) = %+v, want %+v", videoID, lang, got, want)
+	}
+}
+
+func TestFileCacheGetExpired(t *testing.T) {
+	c := &FileCache{dir: t.TempDir()}
+
+	c.Put("dQw4w9WgXcQ", "en", []Transcript{{Text: "hello"}}, -time.Minute)
+
+	if _, ok := c.Get("dQw4w9WgXcQ", "en"); ok {
+		t.Errorf("Get returned ok=true for an expired entry")
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// innertubePlayerURL is the YouTube internal API endpoint used to fetch a
+// player response (including caption tracks) without scraping watch-page
+// HTML.
+const innertubePlayerURL = "https://www.youtube.com/youtubei/v1/player"
+
+// clientInfo describes an innertube client that YouTube will serve a player
+// response to. Caption availability (and the restrictions placed on it, such
+// as age-gating or sign-in requirements) varies by client, which is why
+// yttext falls back across several of them instead of relying solely on the
+// web watch-page scrape.
+type clientInfo struct {
+	name      string
+	version   string
+	userAgent string
+
+	// params is an opaque, client-specific value some innertube clients
+	// require in the request body (observed on Android) to unlock player
+	// data that would otherwise be withheld.
+	params string
+}
+
+// innertubeClients lists, in fallback order, the clients tried when the web
+// watch-page scrape fails to produce caption tracks.
+var innertubeClients = []clientInfo{
+	{
+		name:      "ANDROID",
+		version:   "19.09.37",
+		userAgent: "com.google.android.youtube/19.09.37 (Linux; U; Android 14) gzip",
+		params:    "CgIQBg==",
+	},
+	{
+		name:      "IOS",
+		version:   "19.09.3",
+		userAgent: "com.google.ios.youtube/19.09.3 (iPhone16,2; U; CPU iOS 17_5 like Mac OS X)",
+	},
+	{
+		name:      "TVHTML5_SIMPLY_EMBEDDED_PLAYER",
+		version:   "2.0",
+		userAgent: "Mozilla/5.0 (PlayStation; PlayStation 4/12.00) AppleWebKit/605.1.15 (KHTML, like Gecko)",
+	},
+}
+
+// fetchCaptionsJSONWithFallback scrapes the watch page for caption metadata
+// and, if that fails (transcripts disabled, a captcha challenge, or no
+// captionTracks at all), retries against the innertube player endpoint with
+// each client in innertubeClients until one returns caption tracks.
+func (api *TranscriptAPI) fetchCaptionsJSONWithFallback(ctx context.Context, videoID string) (map[string]interface{}, error) {
+	lastErr := fmt.Errorf("no caption source attempted")
+
+	if html, err := api.fetchVideoHTML(ctx, videoID); err != nil {
+		lastErr = err
+	} else if captionsJSON, err := api.extractCaptionsJSON(html, videoID); err == nil {
+		return captionsJSON, nil
+	} else {
+		lastErr = err
+	}
+
+	for _, client := range innertubeClients {
+		captionsJSON, err := api.fetchCaptionsViaInnertube(ctx, videoID, client)
+		if err == nil {
+			return captionsJSON, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("no caption tracks available from any client: %v", lastErr)
+}
+
+// fetchCaptionsViaInnertube requests a player response for videoID from a
+// specific innertube client and extracts its caption tracklist, mirroring
+// the shape extractCaptionsJSON produces from the watch-page scrape.
+func (api *TranscriptAPI) fetchCaptionsViaInnertube(ctx context.Context, videoID string, client clientInfo) (map[string]interface{}, error) {
+	requestBody := map[string]interface{}{
+		"videoId": videoID,
+		"context": map[string]interface{}{
+			"client": map[string]interface{}{
+				"clientName":    client.name,
+				"clientVersion": client.version,
+			},
+		},
+	}
+	if client.params != "" {
+		requestBody["params"] = client.params
+	}
+
+	payload, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s innertube request: %v", client.name, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", innertubePlayerURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s innertube request: %v", client.name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", client.userAgent)
+
+	resp, err := api.doWithRetry(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s innertube request failed: %v", client.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s innertube request failed (HTTP %d)", client.name, resp.StatusCode)
+	}
+
+	var playerResponse map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&playerResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse %s innertube response: %v", client.name, err)
+	}
+
+	captions, ok := playerResponse["captions"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s innertube response has no captions", client.name)
+	}
+
+	captionsJSON, ok := captions["playerCaptionsTracklistRenderer"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s innertube response has no caption tracklist", client.name)
+	}
+
+	if captionTracks, ok := captionsJSON["captionTracks"].([]interface{}); !ok || len(captionTracks) == 0 {
+		return nil, fmt.Errorf("%s innertube response has no caption tracks", client.name)
+	}
+
+	return captionsJSON, nil
+}
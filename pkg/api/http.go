@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultUserAgent is sent on every scrape request. YouTube commonly serves
+// a recaptcha challenge to requests with an empty or non-browser User-Agent,
+// so a realistic one is used by default.
+const defaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+// defaultRetries and defaultRetryBackoff control retry behavior when it is
+// not configured explicitly via WithRetry.
+const (
+	defaultRetries      = 2
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
+// WithHTTPClient overrides the http.Client used for all outbound requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(api *TranscriptAPI) {
+		api.httpClient = client
+	}
+}
+
+// WithUserAgent overrides the User-Agent sent on scrape requests.
+func WithUserAgent(userAgent string) Option {
+	return func(api *TranscriptAPI) {
+		api.userAgent = userAgent
+	}
+}
+
+// WithProxy routes all outbound requests through proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(api *TranscriptAPI) {
+		api.proxyURL = proxyURL
+	}
+}
+
+// WithRetry configures how many additional attempts are made, with
+// exponential backoff starting at backoff, when a request fails with
+// HTTP 429/5xx or a transport error.
+func WithRetry(retries int, backoff time.Duration) Option {
+	return func(api *TranscriptAPI) {
+		if retries < 0 {
+			retries = 0
+		}
+		api.retries = retries
+		api.retryBackoff = backoff
+	}
+}
+
+// applyProxy wires api.proxyURL into api.httpClient's transport. Called once
+// by New after all options have run.
+func (api *TranscriptAPI) applyProxy() {
+	if api.proxyURL == nil {
+		return
+	}
+
+	transport, ok := api.httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = &http.Transport{}
+	} else {
+		transport = transport.Clone()
+	}
+	transport.Proxy = http.ProxyURL(api.proxyURL)
+	api.httpClient.Transport = transport
+}
+
+// doWithRetry executes req, retrying with exponential backoff and jitter on
+// HTTP 429/5xx responses and transport errors, up to api.retries additional
+// attempts beyond the first. It honors req.Context()'s cancellation and
+// deadline between attempts.
+func (api *TranscriptAPI) doWithRetry(req *http.Request) (*http.Response, error) {
+	retries := api.retries
+	if retries < 0 {
+		retries = 0
+	}
+
+	lastErr := fmt.Errorf("no request attempted")
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
+
+			if err := sleepWithContext(req.Context(), retryDelay(api.retryBackoff, attempt)); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := api.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("request failed (HTTP %d)", resp.StatusCode)
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, lastErr
+}
+
+// retryDelay returns a backoff duration for the given attempt (1-indexed):
+// base doubled per attempt, with jitter that picks a delay between half of
+// that doubled value and the full value.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	backoff := base << uint(attempt-1)
+	jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+	return backoff/2 + jitter
+}
+
+// sleepWithContext waits for d or until ctx is cancelled, whichever comes
+// first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TranscriptTrack describes one caption track available for a video, as
+// listed in the watch page's caption tracklist renderer.
+type TranscriptTrack struct {
+	LanguageCode   string
+	LanguageName   string
+	IsGenerated    bool
+	IsTranslatable bool
+}
+
+// ListAvailableTranscripts returns the caption tracks available for a video,
+// including whether each is an auto-generated (ASR) track and whether
+// YouTube offers to translate it into other languages.
+func (api *TranscriptAPI) ListAvailableTranscripts(ctx context.Context, videoID string) ([]TranscriptTrack, error) {
+	captionsJSON, err := api.fetchCaptionsJSONWithFallback(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	captionTracks, ok := captionsJSON["captionTracks"].([]interface{})
+	if !ok || len(captionTracks) == 0 {
+		return nil, fmt.Errorf("no caption tracks found")
+	}
+
+	var tracks []TranscriptTrack
+	for _, track := range captionTracks {
+		trackMap, ok := track.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		languageCode, ok := trackMap["languageCode"].(string)
+		if !ok {
+			continue
+		}
+
+		languageName := languageCode
+		if nameObj, ok := trackMap["name"].(map[string]interface{}); ok {
+			if simpleText, ok := nameObj["simpleText"].(string); ok {
+				languageName = simpleText
+			}
+		}
+
+		kind, _ := trackMap["kind"].(string)
+		isTranslatable, _ := trackMap["isTranslatable"].(bool)
+
+		tracks = append(tracks, TranscriptTrack{
+			LanguageCode:   languageCode,
+			LanguageName:   languageName,
+			IsGenerated:    kind == "asr",
+			IsTranslatable: isTranslatable,
+		})
+	}
+
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no caption tracks found")
+	}
+
+	return tracks, nil
+}
+
+// ListAvailableTranscriptsByURL is the URL-based counterpart to
+// ListAvailableTranscripts.
+func (api *TranscriptAPI) ListAvailableTranscriptsByURL(ctx context.Context, videoURL string) ([]TranscriptTrack, error) {
+	videoID, err := api.extractVideoID(videoURL)
+	if err != nil {
+		return nil, err
+	}
+	return api.ListAvailableTranscripts(ctx, videoID)
+}
+
+// GetTranslatedTranscript fetches the transcript for a video's srcLang
+// caption track, asking YouTube to machine-translate it into targetLang
+// before returning it.
+func (api *TranscriptAPI) GetTranslatedTranscript(ctx context.Context, videoID, srcLang, targetLang string) ([]Transcript, error) {
+	captionsJSON, err := api.fetchCaptionsJSONWithFallback(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetTrack, err := findCaptionTrack(captionsJSON, srcLang)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, ok := targetTrack["baseUrl"].(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to extract caption track URL")
+	}
+
+	translatedURL := api.withFormatParam(baseURL) + "&tlang=" + url.QueryEscape(targetLang)
+
+	return api.fetchTranscriptFromURL(ctx, translatedURL)
+}
+
+// GetTranslatedTranscriptByURL is the URL-based counterpart to
+// GetTranslatedTranscript.
+func (api *TranscriptAPI) GetTranslatedTranscriptByURL(ctx context.Context, videoURL, srcLang, targetLang string) ([]Transcript, error) {
+	videoID, err := api.extractVideoID(videoURL)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetTranslatedTranscript(ctx, videoID, srcLang, targetLang)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultConcurrency is the number of videos fetched in parallel when no
+// explicit concurrency is configured.
+const defaultConcurrency = 5
+
+// GetTranscriptsByPlaylist fetches transcripts for every video in a YouTube
+// playlist. Videos are fetched concurrently using a bounded worker pool, so a
+// slow or failing video does not block the others. The returned error map
+// contains an entry for every video ID that failed to produce a transcript;
+// video IDs absent from both maps could not be enumerated from the playlist
+// at all.
+func (api *TranscriptAPI) GetTranscriptsByPlaylist(ctx context.Context, playlistURL string, languageCode string) (map[string][]Transcript, map[string]error) {
+	videoIDs, err := api.extractPlaylistVideoIDs(ctx, playlistURL)
+	if err != nil {
+		return nil, map[string]error{playlistURL: err}
+	}
+	return api.fetchTranscriptsConcurrently(ctx, videoIDs, languageCode)
+}
+
+// GetTranscriptsByChannel fetches transcripts for every video on a YouTube
+// channel's videos tab. See GetTranscriptsByPlaylist for the concurrency and
+// error-reporting behavior.
+func (api *TranscriptAPI) GetTranscriptsByChannel(ctx context.Context, channelURL string, languageCode string) (map[string][]Transcript, map[string]error) {
+	videoIDs, err := api.extractChannelVideoIDs(ctx, channelURL)
+	if err != nil {
+		return nil, map[string]error{channelURL: err}
+	}
+	return api.fetchTranscriptsConcurrently(ctx, videoIDs, languageCode)
+}
+
+// extractPlaylistVideoIDs scrapes a YouTube playlist page and returns the
+// video IDs it contains, parsed out of the playlistVideoListRenderer nested
+// inside ytInitialData.
+func (api *TranscriptAPI) extractPlaylistVideoIDs(ctx context.Context, playlistURL string) ([]string, error) {
+	html, err := api.fetchPageHTML(ctx, playlistURL)
+	if err != nil {
+		return nil, err
+	}
+
+	initialData, err := extractYtInitialData(html)
+	if err != nil {
+		return nil, err
+	}
+
+	videoIDs := collectVideoIDs(initialData)
+	if len(videoIDs) == 0 {
+		return nil, fmt.Errorf("no videos found in playlist")
+	}
+
+	return videoIDs, nil
+}
+
+// extractChannelVideoIDs scrapes a YouTube channel's videos tab and returns
+// the video IDs it lists, parsed out of ytInitialData the same way
+// extractPlaylistVideoIDs does for playlists.
+func (api *TranscriptAPI) extractChannelVideoIDs(ctx context.Context, channelURL string) ([]string, error) {
+	videosURL := strings.TrimRight(channelURL, "/")
+	if !strings.HasSuffix(videosURL, "/videos") {
+		videosURL += "/videos"
+	}
+
+	html, err := api.fetchPageHTML(ctx, videosURL)
+	if err != nil {
+		return nil, err
+	}
+
+	initialData, err := extractYtInitialData(html)
+	if err != nil {
+		return nil, err
+	}
+
+	videoIDs := collectVideoIDs(initialData)
+	if len(videoIDs) == 0 {
+		return nil, fmt.Errorf("no videos found on channel")
+	}
+
+	return videoIDs, nil
+}
+
+// extractYtInitialData extracts the ytInitialData blob embedded in a YouTube
+// playlist or channel page, mirroring how extractCaptionsJSON pulls the
+// captions blob out of the watch page.
+func extractYtInitialData(html string) (map[string]interface{}, error) {
+	parts := strings.Split(html, "var ytInitialData = ")
+	if len(parts) <= 1 {
+		return nil, fmt.Errorf("failed to locate ytInitialData")
+	}
+
+	jsonPart := parts[1]
+	endIndex := strings.Index(jsonPart, ";</script>")
+	if endIndex == -1 {
+		return nil, fmt.Errorf("failed to extract ytInitialData")
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonPart[:endIndex]), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ytInitialData: %v", err)
+	}
+
+	return result, nil
+}
+
+// videoIDRendererKeys are the renderer keys whose videoId yttext treats as a
+// playlist/channel entry. Scoping to these (rather than any "videoId" key
+// anywhere in ytInitialData) avoids picking up unrelated video IDs that
+// appear elsewhere on the page, e.g. in sidebar recommendations or ads.
+var videoIDRendererKeys = map[string]bool{
+	"playlistVideoRenderer": true,
+	"videoRenderer":         true,
+}
+
+// collectVideoIDs walks a decoded ytInitialData tree looking for
+// playlistVideoRenderer and videoRenderer entries, returning the videoId
+// values it finds in display order with duplicates removed.
+func collectVideoIDs(node interface{}) []string {
+	var ids []string
+	seen := make(map[string]bool)
+
+	var walk func(interface{})
+	walk = func(n interface{}) {
+		switch v := n.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				if videoIDRendererKeys[key] {
+					if renderer, ok := child.(map[string]interface{}); ok {
+						if id, ok := renderer["videoId"].(string); ok && !seen[id] {
+							seen[id] = true
+							ids = append(ids, id)
+						}
+					}
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+
+	walk(node)
+
+	return ids
+}
+
+// fetchTranscriptsConcurrently fetches transcripts for each video ID using a
+// bounded worker pool sized by api.Concurrency, returning a map of video ID to
+// transcript alongside a map of video ID to the error that occurred while
+// fetching it.
+func (api *TranscriptAPI) fetchTranscriptsConcurrently(ctx context.Context, videoIDs []string, languageCode string) (map[string][]Transcript, map[string]error) {
+	concurrency := api.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make(map[string][]Transcript)
+	errs := make(map[string]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, videoID := range videoIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(videoID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			transcripts, err := api.fetchTranscripts(ctx, videoID, languageCode)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[videoID] = err
+				return
+			}
+			results[videoID] = transcripts
+		}(videoID)
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
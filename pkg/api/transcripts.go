@@ -1,17 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"regexp"
-	"strconv"
 	"strings"
+	"time"
 )
 
-var textRegex = regexp.MustCompile(`<text start="([0-9.]+)" dur="([0-9.]+)".*?>(.*?)</text>`)
+// defaultCacheTTL is used when a Cache is configured via WithCache without an
+// accompanying WithCacheTTL.
+const defaultCacheTTL = 24 * time.Hour
 
 // Transcript represents a single caption/subtitle entry
 type Transcript struct {
@@ -24,27 +26,86 @@ type Transcript struct {
 // TranscriptAPI provides methods to interact with YouTube transcript APIs
 type TranscriptAPI struct {
 	httpClient *http.Client
+
+	// Concurrency controls how many videos are fetched in parallel by
+	// GetTranscriptsByPlaylist and GetTranscriptsByChannel. Zero or negative
+	// values fall back to defaultConcurrency.
+	Concurrency int
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	// Format selects the timedtext response format requested from YouTube.
+	// Defaults to FormatSRV1; set to FormatJSON3 via WithFormat to use the
+	// newer json3 response, which tolerates nested cue markup better than
+	// srv1's XML.
+	Format string
+
+	userAgent    string
+	proxyURL     *url.URL
+	retries      int
+	retryBackoff time.Duration
+}
+
+// Option configures a TranscriptAPI constructed by New.
+type Option func(*TranscriptAPI)
+
+// WithCache configures a Cache that fetchTranscripts consults before
+// contacting YouTube, and populates after a successful fetch.
+func WithCache(cache Cache) Option {
+	return func(api *TranscriptAPI) {
+		api.cache = cache
+	}
+}
+
+// WithCacheTTL sets how long transcripts written to the cache remain valid.
+// It has no effect unless a Cache is also configured via WithCache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(api *TranscriptAPI) {
+		api.cacheTTL = ttl
+	}
+}
+
+// WithFormat selects the timedtext response format requested from YouTube.
+// See the Format field for details.
+func WithFormat(format string) Option {
+	return func(api *TranscriptAPI) {
+		api.Format = format
+	}
 }
 
 // New creates a new TranscriptAPI instance
-func New() *TranscriptAPI {
-	return &TranscriptAPI{
-		httpClient: &http.Client{},
+func New(opts ...Option) *TranscriptAPI {
+	api := &TranscriptAPI{
+		httpClient:   &http.Client{},
+		cacheTTL:     defaultCacheTTL,
+		Format:       FormatSRV1,
+		userAgent:    defaultUserAgent,
+		retries:      defaultRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(api)
 	}
+
+	api.applyProxy()
+
+	return api
 }
 
 // GetTranscripts fetches transcripts for a YouTube video
-func (api *TranscriptAPI) GetTranscripts(videoID string, languageCode string) ([]Transcript, error) {
-	return api.fetchTranscripts(videoID, languageCode)
+func (api *TranscriptAPI) GetTranscripts(ctx context.Context, videoID string, languageCode string) ([]Transcript, error) {
+	return api.fetchTranscripts(ctx, videoID, languageCode)
 }
 
 // GetTranscriptsByURL fetches transcripts for a YouTube video URL
-func (api *TranscriptAPI) GetTranscriptsByURL(videoURL string, languageCode string) ([]Transcript, error) {
+func (api *TranscriptAPI) GetTranscriptsByURL(ctx context.Context, videoURL string, languageCode string) ([]Transcript, error) {
 	videoID, err := api.extractVideoID(videoURL)
 	if err != nil {
 		return nil, err
 	}
-	return api.fetchTranscripts(videoID, languageCode)
+	return api.fetchTranscripts(ctx, videoID, languageCode)
 }
 
 // extractVideoID extracts the video ID from a YouTube URL
@@ -69,28 +130,60 @@ func (api *TranscriptAPI) extractVideoID(videoURL string) (string, error) {
 	return "", fmt.Errorf("could not extract video ID from URL: %s", videoURL)
 }
 
-// fetchTranscripts fetches and processes the transcripts from YouTube
-func (api *TranscriptAPI) fetchTranscripts(videoID string, languageCode string) ([]Transcript, error) {
-	html, err := api.fetchVideoHTML(videoID)
+// fetchTranscripts fetches and processes the transcripts from YouTube,
+// consulting and populating api.cache when one is configured.
+func (api *TranscriptAPI) fetchTranscripts(ctx context.Context, videoID string, languageCode string) ([]Transcript, error) {
+	if languageCode == "" {
+		languageCode = "en"
+	}
+
+	if api.cache != nil {
+		if cached, ok := api.cache.Get(videoID, languageCode); ok {
+			return cached, nil
+		}
+	}
+
+	captionsJSON, err := api.fetchCaptionsJSONWithFallback(ctx, videoID)
 	if err != nil {
 		return nil, err
 	}
 
-	captionsJSON, err := api.extractCaptionsJSON(html, videoID)
+	targetTrack, err := findCaptionTrack(captionsJSON, languageCode)
 	if err != nil {
 		return nil, err
 	}
 
+	baseURL, ok := targetTrack["baseUrl"].(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to extract caption track URL")
+	}
+
+	transcripts, err := api.fetchTranscriptFromURL(ctx, api.withFormatParam(baseURL))
+	if err != nil {
+		return nil, err
+	}
+
+	if api.cache != nil {
+		api.cache.Put(videoID, languageCode, transcripts, api.cacheTTL)
+	}
+
+	return transcripts, nil
+}
+
+// findCaptionTrack picks the caption track matching languageCode out of a
+// caption tracklist, falling back to the first available track if there is
+// no exact match. An empty languageCode defaults to "en".
+func findCaptionTrack(captionsJSON map[string]interface{}, languageCode string) (map[string]interface{}, error) {
 	captionTracks, ok := captionsJSON["captionTracks"].([]interface{})
 	if !ok || len(captionTracks) == 0 {
 		return nil, fmt.Errorf("no caption tracks found")
 	}
 
-	var targetTrack map[string]interface{}
 	if languageCode == "" {
 		languageCode = "en"
 	}
 
+	var targetTrack map[string]interface{}
 	for _, track := range captionTracks {
 		trackMap, ok := track.(map[string]interface{})
 		if !ok {
@@ -108,7 +201,7 @@ func (api *TranscriptAPI) fetchTranscripts(videoID string, languageCode string)
 		}
 	}
 
-	if targetTrack == nil && len(captionTracks) > 0 {
+	if targetTrack == nil {
 		targetTrack, _ = captionTracks[0].(map[string]interface{})
 	}
 
@@ -116,12 +209,19 @@ func (api *TranscriptAPI) fetchTranscripts(videoID string, languageCode string)
 		return nil, fmt.Errorf("no suitable caption track found")
 	}
 
-	baseURL, ok := targetTrack["baseUrl"].(string)
-	if !ok {
-		return nil, fmt.Errorf("failed to extract caption track URL")
+	return targetTrack, nil
+}
+
+// fetchTranscriptFromURL fetches and parses the transcript XML served at a
+// caption track's baseUrl.
+func (api *TranscriptAPI) fetchTranscriptFromURL(ctx context.Context, baseURL string) ([]Transcript, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transcript request: %v", err)
 	}
+	req.Header.Set("User-Agent", api.userAgent)
 
-	resp, err := api.httpClient.Get(baseURL)
+	resp, err := api.doWithRetry(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch transcript XML: %v", err)
 	}
@@ -131,37 +231,99 @@ func (api *TranscriptAPI) fetchTranscripts(videoID string, languageCode string)
 		return nil, fmt.Errorf("failed to fetch transcript (HTTP %d)", resp.StatusCode)
 	}
 
-	xmlData, err := io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read transcript data: %v", err)
 	}
 
-	return api.parseTranscriptXML(xmlData)
+	return api.parseTranscriptResponse(data)
+}
+
+// withFormatParam appends YouTube's fmt query parameter to a caption track's
+// baseUrl when a non-default Format is configured.
+func (api *TranscriptAPI) withFormatParam(baseURL string) string {
+	if api.Format == "" || api.Format == FormatSRV1 {
+		return baseURL
+	}
+	return baseURL + "&fmt=" + api.Format
+}
+
+// parseTranscriptResponse parses a timedtext response in whichever format
+// api.Format selects.
+func (api *TranscriptAPI) parseTranscriptResponse(data []byte) ([]Transcript, error) {
+	if api.Format == FormatJSON3 {
+		return parseTranscriptJSON3(data)
+	}
+	return parseTranscriptSRV1(data)
 }
 
 // fetchVideoHTML fetches the video page HTML
-func (api *TranscriptAPI) fetchVideoHTML(videoID string) (string, error) {
+func (api *TranscriptAPI) fetchVideoHTML(ctx context.Context, videoID string) (string, error) {
 	urlPath := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
-	req, err := http.NewRequest("GET", urlPath, nil)
+	return api.fetchPageHTML(ctx, urlPath)
+}
+
+// fetchPageHTML fetches the HTML for an arbitrary YouTube page (watch,
+// playlist, or channel) using the same headers as fetchVideoHTML. YouTube
+// serves its recaptcha challenge page with a 200 status, so doWithRetry's
+// status-code-based retry never sees it; fetchPageHTML additionally retries,
+// with the same exponential backoff and jitter, whenever the fetched body
+// itself looks like that challenge page.
+func (api *TranscriptAPI) fetchPageHTML(ctx context.Context, urlPath string) (string, error) {
+	retries := api.retries
+	if retries < 0 {
+		retries = 0
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			if err := sleepWithContext(ctx, retryDelay(api.retryBackoff, attempt)); err != nil {
+				return "", err
+			}
+		}
+
+		html, err := api.fetchPageHTMLOnce(ctx, urlPath)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if strings.Contains(html, "class=\"g-recaptcha\"") {
+			lastErr = fmt.Errorf("too many requests")
+			continue
+		}
+
+		return html, nil
+	}
+
+	return "", lastErr
+}
+
+// fetchPageHTMLOnce performs a single fetch of urlPath, relying on
+// doWithRetry for transport-error and HTTP 429/5xx retries.
+func (api *TranscriptAPI) fetchPageHTMLOnce(ctx context.Context, urlPath string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", urlPath, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Set("Accept-Language", "en-US")
+	req.Header.Set("User-Agent", api.userAgent)
 
-	resp, err := api.httpClient.Do(req)
+	resp, err := api.doWithRetry(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch video page: %v", err)
+		return "", fmt.Errorf("failed to fetch page: %v", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("video not found or not accessible (HTTP %d)", resp.StatusCode)
+		return "", fmt.Errorf("page not found or not accessible (HTTP %d)", resp.StatusCode)
 	}
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read video page: %v", err)
+		return "", fmt.Errorf("failed to read page: %v", err)
 	}
 
 	return string(bodyBytes), nil
@@ -199,59 +361,10 @@ func (api *TranscriptAPI) extractCaptionsJSON(html string, videoID string) (map[
 		return nil, fmt.Errorf("transcripts disabled for this video")
 	}
 
-	if _, ok = captionsJSON["captionTracks"]; !ok {
+	captionTracks, ok := captionsJSON["captionTracks"].([]interface{})
+	if !ok || len(captionTracks) == 0 {
 		return nil, fmt.Errorf("no transcript available for this video")
 	}
 
 	return captionsJSON, nil
 }
-
-// parseTranscriptXML parses the transcript XML data into transcript structs
-func (api *TranscriptAPI) parseTranscriptXML(xmlData []byte) ([]Transcript, error) {
-
-	matches := textRegex.FindAllStringSubmatch(string(xmlData), -1)
-
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("no transcript text found in XML")
-	}
-
-	var transcripts []Transcript
-	for _, match := range matches {
-		if len(match) < 4 {
-			continue
-		}
-
-		startTime, err := strconv.ParseFloat(match[1], 64)
-		if err != nil {
-			continue
-		}
-
-		duration, err := strconv.ParseFloat(match[2], 64)
-		if err != nil {
-			continue
-		}
-
-		// Unescape HTML entities in the text
-		text := match[3]
-		text = strings.ReplaceAll(text, "&amp;", "&")
-		text = strings.ReplaceAll(text, "&lt;", "<")
-		text = strings.ReplaceAll(text, "&gt;", ">")
-		text = strings.ReplaceAll(text, "&quot;", "\"")
-		text = strings.ReplaceAll(text, "&#39;", "'")
-
-		transcript := Transcript{
-			Text:      text,
-			Duration:  duration,
-			Offset:    startTime,
-			StartTime: startTime,
-		}
-
-		transcripts = append(transcripts, transcript)
-	}
-
-	if len(transcripts) == 0 {
-		return nil, fmt.Errorf("failed to parse any transcript entries")
-	}
-
-	return transcripts, nil
-}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormatSRV1 requests YouTube's default timedtext XML response.
+	FormatSRV1 = "srv1"
+	// FormatJSON3 requests YouTube's json3 timedtext response, whose event
+	// structure is more tolerant of nested cue markup than srv1's XML.
+	FormatJSON3 = "json3"
+)
+
+// parseTranscriptSRV1 parses an srv1 `<transcript><text start="" dur="">...`
+// response into transcript structs. It walks XML tokens directly rather than
+// unmarshaling into a struct so that nested tags inside a cue (<i>, <b>) and
+// multiline cues contribute their character data instead of being dropped.
+func parseTranscriptSRV1(xmlData []byte) ([]Transcript, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(xmlData))
+
+	var transcripts []Transcript
+	var current *Transcript
+	var text strings.Builder
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse transcript XML: %v", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "text" {
+				continue
+			}
+
+			entry := Transcript{}
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "start":
+					entry.StartTime, _ = strconv.ParseFloat(attr.Value, 64)
+					entry.Offset = entry.StartTime
+				case "dur":
+					entry.Duration, _ = strconv.ParseFloat(attr.Value, 64)
+				}
+			}
+			current = &entry
+			text.Reset()
+		case xml.CharData:
+			if current != nil {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local != "text" || current == nil {
+				continue
+			}
+			current.Text = html.UnescapeString(text.String())
+			transcripts = append(transcripts, *current)
+			current = nil
+		}
+	}
+
+	if len(transcripts) == 0 {
+		return nil, fmt.Errorf("no transcript text found in XML")
+	}
+
+	return transcripts, nil
+}
+
+// json3Response is the shape of YouTube's `&fmt=json3` timedtext response.
+type json3Response struct {
+	Events []json3Event `json:"events"`
+}
+
+type json3Event struct {
+	TStartMs    float64    `json:"tStartMs"`
+	DDurationMs float64    `json:"dDurationMs"`
+	Segs        []json3Seg `json:"segs"`
+}
+
+type json3Seg struct {
+	UTF8 string `json:"utf8"`
+}
+
+// parseTranscriptJSON3 parses a json3 timedtext response into transcript
+// structs, concatenating each event's segments into a single cue.
+func parseTranscriptJSON3(data []byte) ([]Transcript, error) {
+	var response json3Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse json3 transcript: %v", err)
+	}
+
+	var transcripts []Transcript
+	for _, event := range response.Events {
+		if len(event.Segs) == 0 {
+			continue
+		}
+
+		var text strings.Builder
+		for _, seg := range event.Segs {
+			text.WriteString(seg.UTF8)
+		}
+
+		if strings.TrimSpace(text.String()) == "" {
+			continue
+		}
+
+		startTime := event.TStartMs / 1000
+		duration := event.DDurationMs / 1000
+
+		transcripts = append(transcripts, Transcript{
+			Text:      html.UnescapeString(text.String()),
+			Duration:  duration,
+			Offset:    startTime,
+			StartTime: startTime,
+		})
+	}
+
+	if len(transcripts) == 0 {
+		return nil, fmt.Errorf("no transcript text found in json3 response")
+	}
+
+	return transcripts, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestParseTranscriptSRV1NestedTagsAndMultiline(t *testing.T) {
+	xmlData := []byte(`<?xml version="1.0" encoding="utf-8" ?><transcript>` +
+		`<text start="1.5" dur="2.5">Hello <i>world</i></text>` +
+		"<text start=\"4\" dur=\"3\">line one\nline two</text>" +
+		`<text start="8" dur="1">quotes &quot;like this&quot; and &#x27;this&#x27; &amp; more</text>` +
+		`</transcript>`)
+
+	transcripts, err := parseTranscriptSRV1(xmlData)
+	if err != nil {
+		t.Fatalf("parseTranscriptSRV1 returned error: %v", err)
+	}
+	if len(transcripts) != 3 {
+		t.Fatalf("got %d transcripts, want 3: %+v", len(transcripts), transcripts)
+	}
+
+	if got, want := transcripts[0].Text, "Hello world"; got != want {
+		t.Errorf("nested tag cue text = %q, want %q", got, want)
+	}
+	if got, want := transcripts[0].StartTime, 1.5; got != want {
+		t.Errorf("StartTime = %v, want %v", got, want)
+	}
+	if got, want := transcripts[0].Duration, 2.5; got != want {
+		t.Errorf("Duration = %v, want %v", got, want)
+	}
+
+	if got, want := transcripts[1].Text, "line one\nline two"; got != want {
+		t.Errorf("multiline cue text = %q, want %q", got, want)
+	}
+
+	if got, want := transcripts[2].Text, `quotes "like this" and 'this' & more`; got != want {
+		t.Errorf("entity-unescaped cue text = %q, want %q", got, want)
+	}
+}
+
+func TestParseTranscriptSRV1NoText(t *testing.T) {
+	_, err := parseTranscriptSRV1([]byte(`<transcript></transcript>`))
+	if err == nil {
+		t.Error("expected an error for a transcript with no text entries, got nil")
+	}
+}
+
+func TestParseTranscriptJSON3(t *testing.T) {
+	data := []byte(`{
+		"events": [
+			{"tStartMs": 1500, "dDurationMs": 2500, "segs": [{"utf8": "Hello "}, {"utf8": "world"}]},
+			{"tStartMs": 4000, "dDurationMs": 3000, "segs": [{"utf8": "quotes &quot;like this&quot; &#x27;ok&#x27;"}]},
+			{"tStartMs": 8000, "dDurationMs": 1000, "segs": [{"utf8": "   "}]},
+			{"tStartMs": 9000, "dDurationMs": 1000, "segs": []}
+		]
+	}`)
+
+	transcripts, err := parseTranscriptJSON3(data)
+	if err != nil {
+		t.Fatalf("parseTranscriptJSON3 returned error: %v", err)
+	}
+	if len(transcripts) != 2 {
+		t.Fatalf("got %d transcripts, want 2 (blank/empty-seg events should be skipped): %+v", len(transcripts), transcripts)
+	}
+
+	if got, want := transcripts[0].Text, "Hello world"; got != want {
+		t.Errorf("concatenated segs = %q, want %q", got, want)
+	}
+	if got, want := transcripts[0].StartTime, 1.5; got != want {
+		t.Errorf("StartTime = %v, want %v", got, want)
+	}
+
+	if got, want := transcripts[1].Text, `quotes "like this" 'ok'`; got != want {
+		t.Errorf("entity-unescaped text = %q, want %q", got, want)
+	}
+}
+
+func TestParseTranscriptJSON3NoEvents(t *testing.T) {
+	_, err := parseTranscriptJSON3([]byte(`{"events": []}`))
+	if err == nil {
+		t.Error("expected an error for a response with no events, got nil")
+	}
+}
@@ -119,6 +119,70 @@ func (f *SRTFormatter) Format(transcripts []api.Transcript) (string, error) {
 	return sb.String(), nil
 }
 
+// WebVTTFormatter formats transcripts as WebVTT captions
+type WebVTTFormatter struct{}
+
+// Format implements the Formatter interface for WebVTTFormatter
+func (f *WebVTTFormatter) Format(transcripts []api.Transcript) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n\n")
+	for i, t := range transcripts {
+		startTime := t.StartTime
+		endTime := startTime + t.Duration
+
+		sb.WriteString(fmt.Sprintf("%d\n", i+1))
+		sb.WriteString(fmt.Sprintf("%s --> %s\n", formatWebVTTTime(startTime), formatWebVTTTime(endTime)))
+		sb.WriteString(fmt.Sprintf("%s\n\n", escapeWebVTT(t.Text)))
+	}
+	return sb.String(), nil
+}
+
+// TTMLFormatter formats transcripts as Timed Text Markup Language (TTML)
+type TTMLFormatter struct{}
+
+// Format implements the Formatter interface for TTMLFormatter
+func (f *TTMLFormatter) Format(transcripts []api.Transcript) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	sb.WriteString(`<tt xmlns="http://www.w3.org/ns/ttml">` + "\n")
+	sb.WriteString("  <body>\n    <div>\n")
+	for i, t := range transcripts {
+		startTime := t.StartTime
+		endTime := startTime + t.Duration
+
+		sb.WriteString(fmt.Sprintf(
+			`      <p begin="%s" end="%s" xml:id="cue%d">%s</p>`+"\n",
+			formatTTMLTime(startTime), formatTTMLTime(endTime), i+1, escapeXML(t.Text),
+		))
+	}
+	sb.WriteString("    </div>\n  </body>\n</tt>\n")
+	return sb.String(), nil
+}
+
+// escapeWebVTT escapes the characters WebVTT cue text reserves for inline
+// markup ("<b>", "<i>", "<c>", ...) and character references, so literal
+// "&"/"<"/">" in caption text aren't misread as the start of one.
+func escapeWebVTT(text string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+	)
+	return replacer.Replace(text)
+}
+
+// escapeXML escapes the characters that are not valid inside TTML text content
+func escapeXML(text string) string {
+	replacer := strings.NewReplacer(
+		"&", "&amp;",
+		"<", "&lt;",
+		">", "&gt;",
+		"\"", "&quot;",
+		"'", "&apos;",
+	)
+	return replacer.Replace(text)
+}
+
 // formatTime formats time in MM:SS or HH:MM:SS format
 func formatTime(seconds float64) string {
 	duration := time.Duration(seconds * float64(time.Second))
@@ -143,6 +207,22 @@ func formatSRTTime(seconds float64) string {
 	return fmt.Sprintf("%02d:%02d:%02d,%03d", h, m, s, ms)
 }
 
+// formatWebVTTTime formats time in WebVTT format (HH:MM:SS.mmm)
+func formatWebVTTTime(seconds float64) string {
+	duration := time.Duration(seconds * float64(time.Second))
+	h := int(duration.Hours())
+	m := int(duration.Minutes()) % 60
+	s := int(duration.Seconds()) % 60
+	ms := int((seconds - float64(int(seconds))) * 1000)
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
+
+// formatTTMLTime formats time in TTML clock-time format (HH:MM:SS.mmm)
+func formatTTMLTime(seconds float64) string {
+	return formatWebVTTTime(seconds)
+}
+
 // shouldStartNewParagraph determines if we should start a new paragraph based on content
 func shouldStartNewParagraph(prevText, currText string) bool {
 	// Start a new paragraph if:
@@ -227,6 +307,10 @@ func NewFormatter(formatType string) (Formatter, error) {
 		return &JSONFormatter{Pretty: true}, nil
 	case "srt":
 		return &SRTFormatter{}, nil
+	case "vtt":
+		return &WebVTTFormatter{}, nil
+	case "ttml":
+		return &TTMLFormatter{}, nil
 	case "readable":
 		return &ReadableFormatter{
 			MaxLineLength:    80,